@@ -507,6 +507,11 @@ var (
 		Usage:    "Prune ancient data, is an optional config and disabled by default. Only keep the latest 9w blocks' data,the older blocks' data will be permanently pruned. Notice:the geth/chaindata/ancient dir will be removed, if restart without the flag, the ancient data will start with the previous point that the oldest unpruned block number. Recommends to the user who don't care about the ancient data.",
 		Category: flags.HistoryCategory,
 	}
+	PruneAncientUnsafeFlag = &cli.BoolFlag{
+		Name:     "pruneancient.unsafe",
+		Usage:    "Allow ancient data pruning on an archive node or with an unlimited transaction history",
+		Category: flags.HistoryCategory,
+	}
 	CacheLogSizeFlag = &cli.IntFlag{
 		Name:     "cache.blocklogs",
 		Usage:    "Size (in number of blocks) of the log cache for filtering",
@@ -1897,6 +1902,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 			log.Crit("pruneancient parameter didn't take effect for current syncmode")
 		}
 	}
+	if ctx.IsSet(PruneAncientUnsafeFlag.Name) {
+		cfg.UnsafeAncientPruning = ctx.Bool(PruneAncientUnsafeFlag.Name)
+	}
 	if gcmode := ctx.String(GCModeFlag.Name); gcmode != "full" && gcmode != "archive" {
 		Fatalf("--%s must be either 'full' or 'archive'", GCModeFlag.Name)
 	}
@@ -1969,11 +1977,6 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	}
 	if ctx.IsSet(TriesVerifyModeFlag.Name) {
 		cfg.TriesVerifyMode = *flags.GlobalTextMarshaler(ctx, TriesVerifyModeFlag.Name).(*core.VerifyMode)
-		// If a node sets verify mode to full or insecure, it's a fast node and need
-		// to verify blocks from verify nodes, then it should enable trust protocol.
-		if cfg.TriesVerifyMode.NeedRemoteVerify() {
-			cfg.EnableTrustProtocol = true
-		}
 	}
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheSnapshotFlag.Name) {
 		cfg.SnapshotCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheSnapshotFlag.Name) / 100
@@ -2111,6 +2114,22 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if err := kzg4844.UseCKZG(ctx.String(CryptoKZGFlag.Name) == "ckzg"); err != nil {
 		Fatalf("Failed to set KZG library implementation to %s: %v", ctx.String(CryptoKZGFlag.Name), err)
 	}
+	cfg.ApplyVerifyModeDefaults()
+	if err := cfg.Validate(); err != nil {
+		Fatalf("Invalid eth config: %v", err)
+	}
+	if nodeCfg := stack.Config(); prunesServedHistory(cfg, nodeCfg) {
+		log.Warn("Ancient data pruning enabled on a node serving RPC, historical queries will fail", "http", nodeCfg.HTTPHost != "", "ws", nodeCfg.WSHost != "")
+	}
+}
+
+// prunesServedHistory reports whether a non-light node prunes its ancient data
+// while serving it over HTTP or WebSocket RPC.
+func prunesServedHistory(cfg *ethconfig.Config, nodeCfg *node.Config) bool {
+	if !cfg.PruneAncientData || cfg.SyncMode == downloader.LightSync {
+		return false
+	}
+	return nodeCfg.HTTPHost != "" || nodeCfg.WSHost != ""
 }
 
 // SetDNSDiscoveryDefaults configures DNS discovery with the given URL if
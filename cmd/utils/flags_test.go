@@ -20,6 +20,10 @@ package utils
 import (
 	"reflect"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/node"
 )
 
 func Test_SplitTagsFlag(t *testing.T) {
@@ -62,3 +66,28 @@ func Test_SplitTagsFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestPrunesServedHistory(t *testing.T) {
+	tests := []struct {
+		prune bool
+		mode  downloader.SyncMode
+		http  string
+		ws    string
+		want  bool
+	}{
+		{prune: false, mode: downloader.FullSync, http: "127.0.0.1", want: false},
+		{prune: true, mode: downloader.FullSync, want: false},
+		{prune: true, mode: downloader.FullSync, http: "127.0.0.1", want: true},
+		{prune: true, mode: downloader.SnapSync, ws: "127.0.0.1", want: true},
+		{prune: true, mode: downloader.LightSync, http: "127.0.0.1", want: false},
+	}
+	for i, tt := range tests {
+		cfg := ethconfig.Defaults
+		cfg.PruneAncientData = tt.prune
+		cfg.SyncMode = tt.mode
+
+		if have := prunesServedHistory(&cfg, &node.Config{HTTPHost: tt.http, WSHost: tt.ws}); have != tt.want {
+			t.Errorf("test %d: warning mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
@@ -115,6 +115,10 @@ const (
 
 	rewindBadBlockInterval = 1 * time.Second
 
+	adaptiveTrieProcTarget     = 500 * time.Millisecond // Average block processing time up to which an adaptive flush interval isn't shortened
+	adaptiveTrieTimeoutDivisor = 8                      // Lower bound of an adaptive flush interval, as a fraction of the configured one
+	procTimeAvgWindow          = 16                     // Number of blocks the moving processing time average roughly spans
+
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	//
 	// Changelog:
@@ -146,9 +150,11 @@ const (
 type CacheConfig struct {
 	TrieCleanLimit      int           // Memory allowance (MB) to use for caching trie nodes in memory
 	TrieCleanNoPrefetch bool          // Whether to disable heuristic state prefetching for followup blocks
+	PrefetchWorkers     int           // Number of goroutines used by the import and mining state prefetchers (0 = default)
 	TrieDirtyLimit      int           // Memory limit (MB) at which to start flushing dirty trie nodes to disk
 	TrieDirtyDisabled   bool          // Whether to disable trie write caching and GC altogether (archive node)
 	TrieTimeLimit       time.Duration // Time limit after which to flush the current in-memory trie to disk
+	TrieTimeAdaptive    bool          // Whether to shorten TrieTimeLimit while block processing is slow
 	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
 	Preimages           bool          // Whether to store preimage of trie key to the disk
 	TriesInMemory       uint64        // How many tries keeps in memory
@@ -226,6 +232,7 @@ type BlockChain struct {
 	snaps         *snapshot.Tree                   // Snapshot tree for fast trie leaf access
 	triegc        *prque.Prque[int64, common.Hash] // Priority queue mapping block numbers to tries to gc
 	gcproc        time.Duration                    // Accumulates canonical block processing for trie dumping
+	avgproc       time.Duration                    // Moving average of per-block processing time for adaptive trie dumping
 	commitLock    sync.Mutex                       // CommitLock is used to protect above field from being modified concurrently
 	lastWrite     uint64                           // Last block when the state was flushed
 	flushInterval atomic.Int64                     // Time interval (processing time) after which to flush a state
@@ -360,7 +367,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	bc.forker = NewForkChoice(bc, shouldPreserve)
 	bc.stateCache = state.NewDatabaseWithNodeDB(bc.db, bc.triedb)
 	bc.validator = NewBlockValidator(chainConfig, bc, engine)
-	bc.prefetcher = NewStatePrefetcher(chainConfig, bc, engine)
+	bc.prefetcher = NewStatePrefetcher(chainConfig, bc, engine, cacheConfig.PrefetchWorkers)
 	bc.processor = NewStateProcessor(chainConfig, bc, engine)
 
 	var err error
@@ -1629,7 +1636,7 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 				// Find the next state trie we need to commit
 				chosen := current - bc.triesInMemory
 
-				flushInterval := time.Duration(bc.flushInterval.Load())
+				flushInterval := bc.trieFlushInterval()
 
 				// If we exceeded out time allowance, flush an entire trie to disk
 				if bc.gcproc > flushInterval {
@@ -2111,7 +2118,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		if !setHead {
 			// After merge we expect few side chains. Simply count
 			// all blocks the CL gives us for GC processing time
-			bc.gcproc += proctime
+			bc.trackProcTime(proctime)
 
 			return it.index, nil // Direct block insertion of a single block
 		}
@@ -2125,7 +2132,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 			lastCanon = block
 
 			// Only count canonical blocks for GC processing time
-			bc.gcproc += proctime
+			bc.trackProcTime(proctime)
 
 		case SideStatTy:
 			log.Debug("Inserted forked block", "number", block.Number(), "hash", block.Hash(),
@@ -3105,3 +3112,41 @@ func (bc *BlockChain) SetTrieFlushInterval(interval time.Duration) {
 func (bc *BlockChain) GetTrieFlushInterval() time.Duration {
 	return time.Duration(bc.flushInterval.Load())
 }
+
+// trackProcTime accumulates the processing time of an imported block for trie
+// flushing and folds it into the moving per-block average.
+func (bc *BlockChain) trackProcTime(proctime time.Duration) {
+	bc.gcproc += proctime
+	if bc.avgproc == 0 {
+		bc.avgproc = proctime
+	} else {
+		bc.avgproc = (bc.avgproc*(procTimeAvgWindow-1) + proctime) / procTimeAvgWindow
+	}
+}
+
+// trieFlushInterval returns the block processing time after which an entire
+// in-memory trie is flushed to disk. It's the configured flush interval, which
+// is shortened by AdaptiveTrieTimeout if adaptive flushing is enabled.
+func (bc *BlockChain) trieFlushInterval() time.Duration {
+	interval := time.Duration(bc.flushInterval.Load())
+	if bc.cacheConfig.TrieTimeAdaptive {
+		interval = AdaptiveTrieTimeout(interval, bc.avgproc)
+	}
+	return interval
+}
+
+// AdaptiveTrieTimeout scales a trie flush interval down while blocks are slow to
+// process, so that dirty tries are flushed in smaller chunks instead of stalling
+// a busy node with one large write. Up to adaptiveTrieProcTarget per block the
+// interval is kept, slower blocks shrink it proportionally, down to a floor of
+// timeout/adaptiveTrieTimeoutDivisor.
+func AdaptiveTrieTimeout(timeout, avgProc time.Duration) time.Duration {
+	if avgProc <= adaptiveTrieProcTarget {
+		return timeout
+	}
+	scaled := time.Duration(float64(timeout) * float64(adaptiveTrieProcTarget) / float64(avgProc))
+	if floor := timeout / adaptiveTrieTimeoutDivisor; scaled < floor {
+		return floor
+	}
+	return scaled
+}
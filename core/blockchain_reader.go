@@ -358,6 +358,10 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 // Config retrieves the chain's fork configuration.
 func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig }
 
+// PrefetchWorkers retrieves the number of goroutines the state prefetchers are
+// configured to use, 0 meaning the built-in default.
+func (bc *BlockChain) PrefetchWorkers() int { return bc.cacheConfig.PrefetchWorkers }
+
 // Engine retrieves the blockchain's consensus engine.
 func (bc *BlockChain) Engine() consensus.Engine { return bc.engine }
 
@@ -4465,3 +4465,55 @@ func TestEIP3651(t *testing.T) {
 		t.Fatalf("sender balance incorrect: expected %d, got %d", expected, actual)
 	}
 }
+
+func TestAdaptiveTrieFlushInterval(t *testing.T) {
+	engine := ethash.NewFaker()
+	genesis := &Genesis{
+		Config:  params.TestChainConfig,
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, 4, func(i int, b *BlockGen) { b.SetCoinbase(common.Address{1}) })
+
+	newChain := func(adaptive bool) *BlockChain {
+		config := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+		config.TrieTimeAdaptive = adaptive
+
+		chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), config, genesis, nil, engine, vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create tester chain: %v", err)
+		}
+		return chain
+	}
+	// Importing blocks must feed the processing time average
+	chain := newChain(true)
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if chain.avgproc == 0 {
+		t.Fatalf("block processing time not tracked")
+	}
+	// Slow blocks shrink the flush interval, fast blocks restore it
+	limit := chain.GetTrieFlushInterval()
+
+	chain.avgproc = 0
+	chain.trackProcTime(2 * adaptiveTrieProcTarget)
+	if have, want := chain.trieFlushInterval(), limit/2; have != want {
+		t.Errorf("slow import flush interval mismatch: have %v, want %v", have, want)
+	}
+	for i := 0; i < 10*procTimeAvgWindow; i++ {
+		chain.trackProcTime(0)
+	}
+	if have := chain.trieFlushInterval(); have != limit {
+		t.Errorf("fast import flush interval mismatch: have %v, want %v", have, limit)
+	}
+	// Without adaptive flushing the configured interval always applies
+	static := newChain(false)
+	defer static.Stop()
+
+	static.trackProcTime(100 * adaptiveTrieProcTarget)
+	if have := static.trieFlushInterval(); have != limit {
+		t.Errorf("static flush interval mismatch: have %v, want %v", have, limit)
+	}
+}
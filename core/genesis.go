@@ -278,8 +278,10 @@ func (e *GenesisMismatchError) Error() string {
 
 // ChainOverrides contains the changes to chain config.
 type ChainOverrides struct {
-	OverrideCancun *uint64
-	OverrideVerkle *uint64
+	OverrideLondon   *big.Int
+	OverrideShanghai *uint64
+	OverrideCancun   *uint64
+	OverrideVerkle   *uint64
 }
 
 // SetupGenesisBlock writes or updates the genesis block in db.
@@ -305,6 +307,12 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, triedb *trie.Database, gen
 	}
 	applyOverrides := func(config *params.ChainConfig) {
 		if config != nil {
+			if overrides != nil && overrides.OverrideLondon != nil {
+				config.LondonBlock = overrides.OverrideLondon
+			}
+			if overrides != nil && overrides.OverrideShanghai != nil {
+				config.ShanghaiTime = overrides.OverrideShanghai
+			}
 			if overrides != nil && overrides.OverrideCancun != nil {
 				config.CancunTime = overrides.OverrideCancun
 			}
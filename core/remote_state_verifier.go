@@ -434,7 +434,7 @@ func (mode *VerifyMode) UnmarshalText(text []byte) error {
 	case "none":
 		*mode = NoneVerify
 	default:
-		return fmt.Errorf(`unknown sync mode %q, want "full", "light" or "insecure"`, text)
+		return fmt.Errorf(`unknown verify mode %q, want "local", "full", "insecure" or "none"`, text)
 	}
 	return nil
 }
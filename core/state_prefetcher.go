@@ -31,17 +31,23 @@ const checkInterval = 10
 // of an arbitrary state with the goal of prefetching potentially useful state
 // data from disk before the main block processor start executing.
 type statePrefetcher struct {
-	config *params.ChainConfig // Chain configuration options
-	bc     *BlockChain         // Canonical block chain
-	engine consensus.Engine    // Consensus engine used for block rewards
+	config  *params.ChainConfig // Chain configuration options
+	bc      *BlockChain         // Canonical block chain
+	engine  consensus.Engine    // Consensus engine used for block rewards
+	threads int                 // Number of goroutines used to prefetch
 }
 
-// NewStatePrefetcher initialises a new statePrefetcher.
-func NewStatePrefetcher(config *params.ChainConfig, bc *BlockChain, engine consensus.Engine) *statePrefetcher {
+// NewStatePrefetcher initialises a new statePrefetcher running the given number
+// of goroutines, or prefetchThread if threads is not positive.
+func NewStatePrefetcher(config *params.ChainConfig, bc *BlockChain, engine consensus.Engine, threads int) *statePrefetcher {
+	if threads <= 0 {
+		threads = prefetchThread
+	}
 	return &statePrefetcher{
-		config: config,
-		bc:     bc,
-		engine: engine,
+		config:  config,
+		bc:      bc,
+		engine:  engine,
+		threads: threads,
 	}
 }
 
@@ -54,9 +60,9 @@ func (p *statePrefetcher) Prefetch(block *types.Block, statedb *state.StateDB, c
 		signer = types.MakeSigner(p.config, header.Number, header.Time)
 	)
 	transactions := block.Transactions()
-	txChan := make(chan int, prefetchThread)
+	txChan := make(chan int, p.threads)
 	// No need to execute the first batch, since the main processor will do it.
-	for i := 0; i < prefetchThread; i++ {
+	for i := 0; i < p.threads; i++ {
 		go func() {
 			newStatedb := statedb.CopyDoPrefetch()
 			newStatedb.EnableWriteOnSharedStorage()
@@ -101,8 +107,8 @@ func (p *statePrefetcher) Prefetch(block *types.Block, statedb *state.StateDB, c
 func (p *statePrefetcher) PrefetchMining(txs TransactionsByPriceAndNonce, header *types.Header, gasLimit uint64, statedb *state.StateDB, cfg vm.Config, interruptCh <-chan struct{}, txCurr **types.Transaction) {
 	var signer = types.MakeSigner(p.config, header.Number, header.Time)
 
-	txCh := make(chan *types.Transaction, 2*prefetchThread)
-	for i := 0; i < prefetchThread; i++ {
+	txCh := make(chan *types.Transaction, 2*p.threads)
+	for i := 0; i < p.threads; i++ {
 		go func(startCh <-chan *types.Transaction, stopCh <-chan struct{}) {
 			idx := 0
 			newStatedb := statedb.CopyDoPrefetch()
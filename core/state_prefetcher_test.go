@@ -147,3 +147,24 @@ func matchesLabel(sample *profile.Sample, key, expectedValue string) bool {
 
 	return false
 }
+
+func TestPrefetchWorkers(t *testing.T) {
+	gspec := &Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+	for _, tt := range []struct{ workers, threads int }{{0, prefetchThread}, {-1, prefetchThread}, {8, 8}} {
+		cacheConfig := *defaultCacheConfig
+		cacheConfig.PrefetchWorkers = tt.workers
+
+		chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), &cacheConfig, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create tester chain: %v", err)
+		}
+		if have := chain.prefetcher.(*statePrefetcher).threads; have != tt.threads {
+			t.Errorf("workers %d: chain prefetcher threads mismatch: have %d, want %d", tt.workers, have, tt.threads)
+		}
+		// The miner builds its own prefetcher from the chain's setting
+		if have := NewStatePrefetcher(gspec.Config, chain, chain.Engine(), chain.PrefetchWorkers()).threads; have != tt.threads {
+			t.Errorf("workers %d: mining prefetcher threads mismatch: have %d, want %d", tt.workers, have, tt.threads)
+		}
+		chain.Stop()
+	}
+}
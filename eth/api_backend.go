@@ -390,10 +390,18 @@ func (b *EthAPIBackend) RPCGasCap() uint64 {
 	return b.eth.config.RPCGasCap
 }
 
+func (b *EthAPIBackend) RPCGasCapFor(namespace string) uint64 {
+	return b.eth.config.GasCapFor(namespace)
+}
+
 func (b *EthAPIBackend) RPCEVMTimeout() time.Duration {
 	return b.eth.config.RPCEVMTimeout
 }
 
+func (b *EthAPIBackend) RPCEVMTimeoutFor(method string) (time.Duration, bool) {
+	return b.eth.config.EVMTimeoutFor(method)
+}
+
 func (b *EthAPIBackend) RPCTxFeeCap() float64 {
 	return b.eth.config.RPCTxFeeCap
 }
@@ -206,9 +206,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		cacheConfig = &core.CacheConfig{
 			TrieCleanLimit:      config.TrieCleanCache,
 			TrieCleanNoPrefetch: config.NoPrefetch,
+			PrefetchWorkers:     config.PrefetchWorkers,
 			TrieDirtyLimit:      config.TrieDirtyCache,
 			TrieDirtyDisabled:   config.NoPruning,
 			TrieTimeLimit:       config.TrieTimeout,
+			TrieTimeAdaptive:    config.TrieTimeoutAdaptive,
 			NoTries:             config.TriesVerifyMode != core.LocalVerify,
 			SnapshotLimit:       config.SnapshotCache,
 			TriesInMemory:       config.TriesInMemory,
@@ -232,6 +234,12 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	bcOps = append(bcOps, core.EnableBlockValidator(chainConfig, eth.engine, config.TriesVerifyMode, peers))
 	// Override the chain config with provided settings.
 	var overrides core.ChainOverrides
+	if config.OverrideLondon != nil {
+		overrides.OverrideLondon = config.OverrideLondon
+	}
+	if config.OverrideShanghai != nil {
+		overrides.OverrideShanghai = config.OverrideShanghai
+	}
 	if config.OverrideCancun != nil {
 		overrides.OverrideCancun = config.OverrideCancun
 	}
@@ -586,7 +594,7 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 	if !s.config.DisableSnapProtocol && s.config.SnapshotCache > 0 {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler), s.snapDialCandidates)...)
 	}
-	if s.config.EnableTrustProtocol {
+	if s.config.TrustProtocolEnabled() {
 		protos = append(protos, trust.MakeProtocols((*trustHandler)(s.handler), s.snapDialCandidates)...)
 	}
 	protos = append(protos, bsc.MakeProtocols((*bscHandler)(s.handler), s.bscDialCandidates)...)
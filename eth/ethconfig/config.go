@@ -19,6 +19,10 @@ package ethconfig
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -37,8 +41,20 @@ import (
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/naoina/toml"
 )
 
+// tomlSettings mirrors the settings used by geth so that TOML keys use the
+// same names as Go struct fields.
+var tomlSettings = toml.Config{
+	NormFieldName: func(rt reflect.Type, key string) string {
+		return key
+	},
+	FieldToKey: func(rt reflect.Type, field string) string {
+		return field
+	},
+}
+
 // FullNodeGPO contains default gasprice oracle settings for full node.
 var FullNodeGPO = gasprice.Config{
 	Blocks:          20,
@@ -118,6 +134,10 @@ type Config struct {
 	PipeCommit          bool
 	RangeLimit          bool
 
+	// PrefetchWorkers is the number of goroutines the state prefetchers of both
+	// block import and mining use, 0 keeping the built-in default.
+	PrefetchWorkers int `toml:",omitempty"`
+
 	// Deprecated, use 'TransactionHistory' instead.
 	TxLookupLimit      uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 	TransactionHistory uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
@@ -144,7 +164,7 @@ type Config struct {
 	DatabaseFreezer    string
 	DatabaseDiff       string
 	PersistDiff        bool
-	DiffBlock          uint64
+	DiffBlock          uint64 // Number of blocks of diff layers kept on disk when PersistDiff is set
 	// PruneAncientData is an optional config and disabled by default, and usually you do not need it.
 	// When this flag is enabled, only keep the latest 9w blocks' data, the older blocks' data will be
 	// pruned instead of being dumped to freezerdb, the pruned data includes CanonicalHash, Header, Block,
@@ -153,6 +173,9 @@ type Config struct {
 	// if restart without the pruneancient flag, the ancient data will start with the previous point that
 	// the oldest unpruned block number.
 	PruneAncientData bool
+	// UnsafeAncientPruning allows PruneAncientData on a node that is otherwise
+	// configured to keep full history (--pruneancient.unsafe), see AncientPruningSafe.
+	UnsafeAncientPruning bool `toml:",omitempty"`
 
 	TrieCleanCache  int
 	TrieDirtyCache  int
@@ -162,6 +185,11 @@ type Config struct {
 	TriesVerifyMode core.VerifyMode
 	Preimages       bool
 
+	// TrieTimeoutAdaptive shortens the dirty trie flush interval while block
+	// imports are slow, keeping TrieTimeout as the upper bound. See
+	// EffectiveTrieTimeout.
+	TrieTimeoutAdaptive bool `toml:",omitempty"`
+
 	// This is the number of blocks for which logs will be cached in the filter system.
 	FilterLogCacheSize int
 
@@ -184,13 +212,30 @@ type Config struct {
 	// RPCGasCap is the global gas cap for eth-call variants.
 	RPCGasCap uint64
 
+	// RPCGasCaps optionally overrides RPCGasCap for individual RPC namespaces,
+	// keyed by namespace name (e.g. "eth", "debug"). GraphQL is not an RPC
+	// namespace and always uses RPCGasCap.
+	RPCGasCaps map[string]uint64 `toml:",omitempty"`
+
 	// RPCEVMTimeout is the global timeout for eth-call.
 	RPCEVMTimeout time.Duration
 
+	// RPCEVMTimeouts optionally overrides the EVM execution timeout of
+	// individual RPC methods, keyed by full method name. For eth_call it takes
+	// the place of RPCEVMTimeout, for the debug_trace* methods of the default
+	// trace timeout used when the caller doesn't request one.
+	RPCEVMTimeouts map[string]time.Duration `toml:",omitempty"`
+
 	// RPCTxFeeCap is the global transaction fee(price * gaslimit) cap for
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// OverrideLondon (TODO: remove after the fork)
+	OverrideLondon *big.Int `toml:",omitempty"`
+
+	// OverrideShanghai (TODO: remove after the fork)
+	OverrideShanghai *uint64 `toml:",omitempty"`
+
 	// OverrideCancun (TODO: remove after the fork)
 	OverrideCancun *uint64 `toml:",omitempty"`
 
@@ -198,6 +243,156 @@ type Config struct {
 	OverrideVerkle *uint64 `toml:",omitempty"`
 }
 
+// Validate checks the configuration for contradictory or out-of-range settings.
+// All detected problems are reported together in the returned error.
+func (c *Config) Validate() error {
+	var errs []string
+	if c.TriesInMemory == 0 {
+		errs = append(errs, "TriesInMemory must be greater than 0")
+	}
+	// eth.New is the authoritative check for the verify mode, as it also guards
+	// configs that never pass through here. This copy only lets the CLI report
+	// it together with the other problems, before any database is opened.
+	if !c.TriesVerifyMode.IsValid() {
+		errs = append(errs, fmt.Sprintf("invalid TriesVerifyMode %d", c.TriesVerifyMode))
+	}
+	if c.PersistDiff && c.DiffBlock < c.TriesInMemory {
+		errs = append(errs, fmt.Sprintf("DiffBlock (%d) must not be smaller than TriesInMemory (%d)", c.DiffBlock, c.TriesInMemory))
+	}
+	if c.PrefetchWorkers < 0 {
+		errs = append(errs, fmt.Sprintf("PrefetchWorkers must be non-negative, have %d", c.PrefetchWorkers))
+	}
+	if c.LightIngress < 0 {
+		errs = append(errs, fmt.Sprintf("LightIngress must be non-negative, have %d", c.LightIngress))
+	}
+	if c.LightEgress < 0 {
+		errs = append(errs, fmt.Sprintf("LightEgress must be non-negative, have %d", c.LightEgress))
+	}
+	if c.PruneAncientData && !c.UnsafeAncientPruning {
+		if c.NoPruning {
+			errs = append(errs, "PruneAncientData conflicts with NoPruning, set UnsafeAncientPruning to override")
+		}
+		if c.TransactionHistory == 0 {
+			errs = append(errs, "PruneAncientData conflicts with unlimited TransactionHistory, set UnsafeAncientPruning to override")
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ApplyVerifyModeDefaults fills in TriesInMemory and SnapshotCache when they are
+// left zero, picking values suited to the configured TriesVerifyMode. In local
+// mode a zero SnapshotCache means the snapshot is disabled, so it is left alone.
+// Nodes in the full, insecure and none modes keep no tries and can't run without
+// the snapshot, so the allowance folded into TrieCleanCache when the snapshot
+// was disabled is moved back, falling back to the default snapshot cache.
+func (c *Config) ApplyVerifyModeDefaults() {
+	if c.TriesInMemory == 0 {
+		c.TriesInMemory = Defaults.TriesInMemory
+	}
+	if c.SnapshotCache == 0 && c.TriesVerifyMode != core.LocalVerify {
+		c.SnapshotCache, c.TrieCleanCache = c.TrieCleanCache, 0
+		if c.SnapshotCache == 0 {
+			c.SnapshotCache = Defaults.SnapshotCache
+		}
+	}
+}
+
+// TrustProtocolEnabled reports whether the trust protocol should be registered.
+// It is enabled either explicitly through EnableTrustProtocol, or implicitly
+// when TriesVerifyMode is full or insecure: such a node is a fast node that
+// needs remote verify nodes to check its blocks, so it can't run without the
+// trust protocol regardless of EnableTrustProtocol.
+func (c *Config) TrustProtocolEnabled() bool {
+	return c.EnableTrustProtocol || c.TriesVerifyMode.NeedRemoteVerify()
+}
+
+// GasCapFor returns the eth-call gas cap to use for the given RPC namespace,
+// falling back to the global RPCGasCap if the namespace has no override.
+func (c *Config) GasCapFor(namespace string) uint64 {
+	if gasCap, ok := c.RPCGasCaps[namespace]; ok {
+		return gasCap
+	}
+	return c.RPCGasCap
+}
+
+// EVMTimeoutFor returns the EVM execution timeout override configured for the
+// given RPC method, and whether there is one. Methods without an override use
+// their own default, which is RPCEVMTimeout for eth_call.
+func (c *Config) EVMTimeoutFor(method string) (time.Duration, bool) {
+	timeout, ok := c.RPCEVMTimeouts[method]
+	return timeout, ok
+}
+
+// EffectiveTrieTimeout returns the dirty trie flush interval the block chain
+// uses given the recent average time spent importing a block. Unless
+// TrieTimeoutAdaptive is set this is simply TrieTimeout, otherwise it's scaled
+// down for slow imports as described in core.AdaptiveTrieTimeout.
+func (c *Config) EffectiveTrieTimeout(recentImport time.Duration) time.Duration {
+	if !c.TrieTimeoutAdaptive {
+		return c.TrieTimeout
+	}
+	return core.AdaptiveTrieTimeout(c.TrieTimeout, recentImport)
+}
+
+// AncientPruningSafe reports whether PruneAncientData is compatible with the
+// rest of the configuration. Pruning ancient data drops old blocks, receipts
+// and transaction indices, which contradicts running as an archive node
+// (NoPruning) or keeping the transaction index for the whole chain
+// (TransactionHistory of 0); historical RPC queries on such a node would
+// silently start failing. Validate rejects such a configuration unless
+// UnsafeAncientPruning is set.
+func (c *Config) AncientPruningSafe() bool {
+	if !c.PruneAncientData {
+		return true
+	}
+	return !c.NoPruning && c.TransactionHistory != 0
+}
+
+// DumpTOML renders the configuration as TOML, in the same layout geth's
+// dumpconfig command uses, for attaching to bug reports. Fields tagged
+// toml:"-" (such as DocRoot, DatabaseHandles and RequiredBlocks) are left out,
+// and so is the genesis block, which is large and rarely relevant.
+func (c *Config) DumpTOML() (string, error) {
+	cfg := *c
+	cfg.Genesis = nil
+
+	out, err := tomlSettings.Marshal(&cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Merge overlays the fields set in override onto c. Nested structs such as the
+// miner or txpool settings are merged field by field, and any other field is
+// copied when it's non-zero, so pointer, slice and map fields only win when
+// non-nil, mirroring UnmarshalTOML. Since zero values can't be told apart from
+// unset ones, a false or zero field in override never clears a value in c.
+func (c *Config) Merge(override *Config) {
+	if override == nil {
+		return
+	}
+	mergeValue(reflect.ValueOf(c).Elem(), reflect.ValueOf(override).Elem())
+}
+
+// mergeValue copies src into dst if src is non-zero, recursing into structs.
+func mergeValue(dst, src reflect.Value) {
+	if src.Kind() == reflect.Struct {
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+		return
+	}
+	if !src.IsZero() {
+		dst.Set(src)
+	}
+}
+
 // CreateConsensusEngine creates a consensus engine for the given chain config.
 // Clique is allowed for now to live standalone, but ethash is forbidden and can
 // only exist on already merged networks.
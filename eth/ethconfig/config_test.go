@@ -0,0 +1,437 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethconfig
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/miner"
+)
+
+// roundTripTOML marshals the config to TOML and decodes it back.
+func roundTripTOML(t *testing.T, cfg Config) Config {
+	t.Helper()
+
+	out, err := tomlSettings.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	var dec Config
+	if err := tomlSettings.Unmarshal(out, &dec); err != nil {
+		t.Fatalf("failed to unmarshal config: %v\n%s", err, out)
+	}
+	return dec
+}
+
+func TestNoPrefetchTOMLRoundTrip(t *testing.T) {
+	cfg := Defaults
+	cfg.NoPrefetch = true
+
+	if dec := roundTripTOML(t, cfg); !dec.NoPrefetch {
+		t.Fatalf("NoPrefetch lost during TOML round-trip")
+	}
+}
+
+func TestPrefetchWorkersTOMLRoundTrip(t *testing.T) {
+	cfg := Defaults
+	cfg.PrefetchWorkers = 8
+
+	if dec := roundTripTOML(t, cfg); dec.PrefetchWorkers != 8 {
+		t.Fatalf("PrefetchWorkers mismatch after TOML round-trip: have %d, want %d", dec.PrefetchWorkers, 8)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		errs   []string
+		absent []string // Problems the error must not mention
+	}{
+		{
+			name:   "defaults",
+			modify: func(c *Config) {},
+		},
+		{
+			name:   "zero tries in memory",
+			modify: func(c *Config) { c.TriesInMemory = 0 },
+			errs:   []string{"TriesInMemory"},
+		},
+		{
+			name:   "negative light ingress",
+			modify: func(c *Config) { c.LightIngress = -1 },
+			errs:   []string{"LightIngress"},
+		},
+		{
+			name:   "negative light egress",
+			modify: func(c *Config) { c.LightEgress = -1 },
+			errs:   []string{"LightEgress"},
+		},
+		{
+			name: "diff retention below tries in memory",
+			modify: func(c *Config) {
+				c.PersistDiff = true
+				c.DiffBlock = c.TriesInMemory - 1
+			},
+			errs: []string{"DiffBlock"},
+		},
+		{
+			name:   "short diff retention without persistence",
+			modify: func(c *Config) { c.DiffBlock = 1 },
+		},
+		{
+			name:   "negative prefetch workers",
+			modify: func(c *Config) { c.PrefetchWorkers = -1 },
+			errs:   []string{"PrefetchWorkers"},
+		},
+		{
+			name:   "out of range verify mode",
+			modify: func(c *Config) { c.TriesVerifyMode = core.NoneVerify + 1 },
+			errs:   []string{"TriesVerifyMode"},
+		},
+		{
+			name: "ancient pruning on archive node",
+			modify: func(c *Config) {
+				c.PruneAncientData = true
+				c.NoPruning = true
+			},
+			errs:   []string{"conflicts with NoPruning"},
+			absent: []string{"TransactionHistory"},
+		},
+		{
+			name: "ancient pruning with unlimited tx history",
+			modify: func(c *Config) {
+				c.PruneAncientData = true
+				c.TransactionHistory = 0
+			},
+			errs:   []string{"conflicts with unlimited TransactionHistory"},
+			absent: []string{"NoPruning"},
+		},
+		{
+			name: "unsafe ancient pruning allowed",
+			modify: func(c *Config) {
+				c.PruneAncientData = true
+				c.NoPruning = true
+				c.UnsafeAncientPruning = true
+			},
+		},
+		{
+			name: "multiple problems",
+			modify: func(c *Config) {
+				c.TriesInMemory = 0
+				c.LightIngress = -1
+				c.LightEgress = -1
+			},
+			errs: []string{"TriesInMemory", "LightIngress", "LightEgress"},
+		},
+	}
+	for _, tt := range tests {
+		cfg := Defaults
+		tt.modify(&cfg)
+
+		err := cfg.Validate()
+		if len(tt.errs) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+			continue
+		}
+		for _, want := range tt.errs {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: error %q does not mention %s", tt.name, err, want)
+			}
+		}
+		for _, unwanted := range tt.absent {
+			if strings.Contains(err.Error(), unwanted) {
+				t.Errorf("%s: error %q wrongly mentions %s", tt.name, err, unwanted)
+			}
+		}
+	}
+}
+
+func TestConfigMerge(t *testing.T) {
+	cancun := uint64(1700000000)
+	override := &Config{
+		NetworkId:      56,
+		OverrideCancun: &cancun,
+		Miner: miner.Config{
+			GasCeil: 140000000,
+		},
+	}
+	cfg := Defaults
+	cfg.Merge(override)
+
+	if cfg.NetworkId != 56 {
+		t.Errorf("NetworkId not overridden: have %d, want %d", cfg.NetworkId, 56)
+	}
+	if cfg.OverrideCancun == nil || *cfg.OverrideCancun != cancun {
+		t.Errorf("OverrideCancun not overridden: have %v, want %d", cfg.OverrideCancun, cancun)
+	}
+	if cfg.Miner.GasCeil != 140000000 {
+		t.Errorf("Miner.GasCeil not overridden: have %d, want %d", cfg.Miner.GasCeil, 140000000)
+	}
+	// Fields left unset in the override must keep their base values.
+	if cfg.OverrideVerkle != nil {
+		t.Errorf("OverrideVerkle unexpectedly set: %v", *cfg.OverrideVerkle)
+	}
+	if cfg.SyncMode != Defaults.SyncMode {
+		t.Errorf("SyncMode changed: have %v, want %v", cfg.SyncMode, Defaults.SyncMode)
+	}
+	if cfg.TriesInMemory != Defaults.TriesInMemory {
+		t.Errorf("TriesInMemory changed: have %d, want %d", cfg.TriesInMemory, Defaults.TriesInMemory)
+	}
+	if cfg.Miner.GasPrice.Cmp(Defaults.Miner.GasPrice) != 0 {
+		t.Errorf("Miner.GasPrice changed: have %v, want %v", cfg.Miner.GasPrice, Defaults.Miner.GasPrice)
+	}
+	if cfg.Miner.Recommit != Defaults.Miner.Recommit {
+		t.Errorf("Miner.Recommit changed: have %v, want %v", cfg.Miner.Recommit, Defaults.Miner.Recommit)
+	}
+	if !reflect.DeepEqual(cfg.TxPool, Defaults.TxPool) {
+		t.Errorf("TxPool changed: have %+v, want %+v", cfg.TxPool, Defaults.TxPool)
+	}
+}
+
+func TestApplyVerifyModeDefaults(t *testing.T) {
+	tests := []struct {
+		mode           core.VerifyMode
+		triesInMemory  uint64
+		snapshotCache  int
+		trieCleanCache int
+	}{
+		{core.LocalVerify, Defaults.TriesInMemory, 0, 200},
+		{core.FullVerify, Defaults.TriesInMemory, 200, 0},
+		{core.InsecureVerify, Defaults.TriesInMemory, 200, 0},
+		{core.NoneVerify, Defaults.TriesInMemory, 200, 0},
+	}
+	for _, tt := range tests {
+		// A disabled snapshot leaves its allowance in the trie clean cache.
+		cfg := Config{TriesVerifyMode: tt.mode, TrieCleanCache: 200}
+		cfg.ApplyVerifyModeDefaults()
+
+		if cfg.TriesInMemory != tt.triesInMemory {
+			t.Errorf("%v: TriesInMemory mismatch: have %d, want %d", tt.mode, cfg.TriesInMemory, tt.triesInMemory)
+		}
+		if cfg.SnapshotCache != tt.snapshotCache {
+			t.Errorf("%v: SnapshotCache mismatch: have %d, want %d", tt.mode, cfg.SnapshotCache, tt.snapshotCache)
+		}
+		if cfg.TrieCleanCache != tt.trieCleanCache {
+			t.Errorf("%v: TrieCleanCache mismatch: have %d, want %d", tt.mode, cfg.TrieCleanCache, tt.trieCleanCache)
+		}
+		// Without any cache allowance the snapshot falls back to its default.
+		cfg = Config{TriesVerifyMode: tt.mode}
+		cfg.ApplyVerifyModeDefaults()
+
+		if tt.mode != core.LocalVerify && cfg.SnapshotCache != Defaults.SnapshotCache {
+			t.Errorf("%v: SnapshotCache fallback mismatch: have %d, want %d", tt.mode, cfg.SnapshotCache, Defaults.SnapshotCache)
+		}
+		// Explicit settings must be left alone.
+		cfg = Config{TriesVerifyMode: tt.mode, TriesInMemory: 32, SnapshotCache: 64}
+		cfg.ApplyVerifyModeDefaults()
+
+		if cfg.TriesInMemory != 32 || cfg.SnapshotCache != 64 {
+			t.Errorf("%v: explicit values overwritten: have %d/%d, want 32/64", tt.mode, cfg.TriesInMemory, cfg.SnapshotCache)
+		}
+	}
+}
+
+func TestVerifyModeTOML(t *testing.T) {
+	var cfg Config
+	if err := tomlSettings.Unmarshal([]byte(`TriesVerifyMode = "insecure"`), &cfg); err != nil {
+		t.Fatalf("failed to decode valid verify mode: %v", err)
+	}
+	if cfg.TriesVerifyMode != core.InsecureVerify {
+		t.Fatalf("verify mode mismatch: have %v, want %v", cfg.TriesVerifyMode, core.InsecureVerify)
+	}
+	if err := tomlSettings.Unmarshal([]byte(`TriesVerifyMode = "bogus"`), &cfg); err == nil {
+		t.Fatal("expected error decoding unknown verify mode")
+	}
+}
+
+func TestTrustProtocolEnabled(t *testing.T) {
+	tests := []struct {
+		enable bool
+		mode   core.VerifyMode
+		want   bool
+	}{
+		{false, core.LocalVerify, false},
+		{true, core.LocalVerify, true},
+		{false, core.FullVerify, true},
+		{true, core.FullVerify, true},
+		{false, core.InsecureVerify, true},
+		{true, core.InsecureVerify, true},
+		{false, core.NoneVerify, false},
+		{true, core.NoneVerify, true},
+	}
+	for _, tt := range tests {
+		cfg := Config{EnableTrustProtocol: tt.enable, TriesVerifyMode: tt.mode}
+		if have := cfg.TrustProtocolEnabled(); have != tt.want {
+			t.Errorf("enable=%v mode=%v: have %v, want %v", tt.enable, tt.mode, have, tt.want)
+		}
+	}
+}
+
+func TestOverridesTOMLRoundTrip(t *testing.T) {
+	// Unset overrides must be omitted.
+	out, err := tomlSettings.Marshal(&Defaults)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	for _, field := range []string{"OverrideLondon", "OverrideShanghai"} {
+		if strings.Contains(string(out), field) {
+			t.Errorf("unset %s not omitted from TOML", field)
+		}
+	}
+	// Set overrides must survive a round-trip.
+	shanghai := uint64(1700000000)
+
+	cfg := Defaults
+	cfg.OverrideLondon = big.NewInt(31302048)
+	cfg.OverrideShanghai = &shanghai
+
+	dec := roundTripTOML(t, cfg)
+	if dec.OverrideLondon == nil || dec.OverrideLondon.Cmp(cfg.OverrideLondon) != 0 {
+		t.Errorf("OverrideLondon mismatch: have %v, want %v", dec.OverrideLondon, cfg.OverrideLondon)
+	}
+	if dec.OverrideShanghai == nil || *dec.OverrideShanghai != shanghai {
+		t.Errorf("OverrideShanghai mismatch: have %v, want %d", dec.OverrideShanghai, shanghai)
+	}
+}
+
+func TestGasCapFor(t *testing.T) {
+	cfg := Defaults
+	cfg.RPCGasCap = 50000000
+	cfg.RPCGasCaps = map[string]uint64{"debug": 500000000}
+
+	if have := cfg.GasCapFor("eth"); have != cfg.RPCGasCap {
+		t.Errorf("eth gas cap mismatch: have %d, want %d", have, cfg.RPCGasCap)
+	}
+	if have := cfg.GasCapFor("debug"); have != 500000000 {
+		t.Errorf("debug gas cap mismatch: have %d, want %d", have, 500000000)
+	}
+	dec := roundTripTOML(t, cfg)
+	if have := dec.GasCapFor("debug"); have != 500000000 {
+		t.Errorf("debug gas cap lost during TOML round-trip: have %d", have)
+	}
+	if have := dec.GasCapFor("eth"); have != cfg.RPCGasCap {
+		t.Errorf("eth gas cap mismatch after round-trip: have %d, want %d", have, cfg.RPCGasCap)
+	}
+}
+
+func TestEVMTimeoutFor(t *testing.T) {
+	cfg := Defaults
+	cfg.RPCEVMTimeouts = map[string]time.Duration{"debug_traceTransaction": time.Minute}
+
+	if timeout, ok := cfg.EVMTimeoutFor("eth_call"); ok {
+		t.Errorf("unexpected eth_call override: %v", timeout)
+	}
+	if timeout, ok := cfg.EVMTimeoutFor("debug_traceTransaction"); !ok || timeout != time.Minute {
+		t.Errorf("debug_traceTransaction override mismatch: have %v (%v), want %v", timeout, ok, time.Minute)
+	}
+	dec := roundTripTOML(t, cfg)
+	if timeout, ok := dec.EVMTimeoutFor("debug_traceTransaction"); !ok || timeout != time.Minute {
+		t.Errorf("debug_traceTransaction override lost during TOML round-trip: have %v (%v)", timeout, ok)
+	}
+}
+
+func TestEffectiveTrieTimeout(t *testing.T) {
+	cfg := Defaults
+	cfg.TrieTimeout = 60 * time.Minute
+
+	tests := []struct {
+		adaptive bool
+		recent   time.Duration
+		want     time.Duration
+	}{
+		// Adaptive mode disabled always yields the configured timeout
+		{false, 10 * time.Second, 60 * time.Minute},
+		// Imports within the target keep the full timeout
+		{true, 0, 60 * time.Minute},
+		{true, 500 * time.Millisecond, 60 * time.Minute},
+		// Slower imports shrink the timeout proportionally
+		{true, time.Second, 30 * time.Minute},
+		{true, 2 * time.Second, 15 * time.Minute},
+		// Very slow imports are bounded by the floor
+		{true, time.Minute, 60 * time.Minute / 8},
+	}
+	for i, tt := range tests {
+		cfg.TrieTimeoutAdaptive = tt.adaptive
+		if have := cfg.EffectiveTrieTimeout(tt.recent); have != tt.want {
+			t.Errorf("test %d: timeout mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+	cfg.TrieTimeoutAdaptive = true
+	if dec := roundTripTOML(t, cfg); !dec.TrieTimeoutAdaptive {
+		t.Fatalf("TrieTimeoutAdaptive lost during TOML round-trip")
+	}
+}
+
+func TestAncientPruningSafe(t *testing.T) {
+	tests := []struct {
+		prune     bool
+		noPruning bool
+		txHistory uint64
+		safe      bool
+	}{
+		{prune: false, noPruning: true, txHistory: 0, safe: true},
+		{prune: true, noPruning: false, txHistory: 2350000, safe: true},
+		{prune: true, noPruning: true, txHistory: 2350000, safe: false},
+		{prune: true, noPruning: false, txHistory: 0, safe: false},
+	}
+	for i, tt := range tests {
+		cfg := Defaults
+		cfg.PruneAncientData = tt.prune
+		cfg.NoPruning = tt.noPruning
+		cfg.TransactionHistory = tt.txHistory
+
+		if have := cfg.AncientPruningSafe(); have != tt.safe {
+			t.Errorf("test %d: safety mismatch: have %v, want %v", i, have, tt.safe)
+		}
+	}
+}
+
+func TestDumpTOML(t *testing.T) {
+	cfg := Defaults
+	cfg.DocRoot = "/secret/docroot"
+	cfg.DatabaseHandles = 4096
+	cfg.SkipBcVersionCheck = true
+	cfg.Genesis = core.DefaultGenesisBlock()
+
+	out, err := cfg.DumpTOML()
+	if err != nil {
+		t.Fatalf("failed to dump config: %v", err)
+	}
+	for _, field := range []string{"DocRoot", "DatabaseHandles", "SkipBcVersionCheck", "RequiredBlocks", "Genesis"} {
+		if strings.Contains(out, field) {
+			t.Errorf("dump contains excluded field %s", field)
+		}
+	}
+	if !strings.Contains(out, "NetworkId") {
+		t.Errorf("dump is missing NetworkId:\n%s", out)
+	}
+	if cfg.Genesis == nil {
+		t.Errorf("dump cleared the genesis of the original config")
+	}
+}
@@ -3,6 +3,7 @@
 package ethconfig
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -32,6 +33,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		EnableTrustProtocol     bool
 		PipeCommit              bool
 		RangeLimit              bool
+		PrefetchWorkers         int                    `toml:",omitempty"`
 		TxLookupLimit           uint64                 `toml:",omitempty"`
 		TransactionHistory      uint64                 `toml:",omitempty"`
 		StateHistory            uint64                 `toml:",omitempty"`
@@ -51,6 +53,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		PersistDiff             bool
 		DiffBlock               uint64
 		PruneAncientData        bool
+		UnsafeAncientPruning    bool `toml:",omitempty"`
 		TrieCleanCache          int
 		TrieDirtyCache          int
 		TrieTimeout             time.Duration
@@ -58,6 +61,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		TriesInMemory           uint64
 		TriesVerifyMode         core.VerifyMode
 		Preimages               bool
+		TrieTimeoutAdaptive     bool `toml:",omitempty"`
 		FilterLogCacheSize      int
 		Miner                   miner.Config
 		TxPool                  legacypool.Config
@@ -66,10 +70,14 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		EnablePreimageRecording bool
 		DocRoot                 string `toml:"-"`
 		RPCGasCap               uint64
+		RPCGasCaps              map[string]uint64 `toml:",omitempty"`
 		RPCEVMTimeout           time.Duration
+		RPCEVMTimeouts          map[string]time.Duration `toml:",omitempty"`
 		RPCTxFeeCap             float64
-		OverrideCancun          *uint64 `toml:",omitempty"`
-		OverrideVerkle          *uint64 `toml:",omitempty"`
+		OverrideLondon          *big.Int `toml:",omitempty"`
+		OverrideShanghai        *uint64  `toml:",omitempty"`
+		OverrideCancun          *uint64  `toml:",omitempty"`
+		OverrideVerkle          *uint64  `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -87,6 +95,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.EnableTrustProtocol = c.EnableTrustProtocol
 	enc.PipeCommit = c.PipeCommit
 	enc.RangeLimit = c.RangeLimit
+	enc.PrefetchWorkers = c.PrefetchWorkers
 	enc.TxLookupLimit = c.TxLookupLimit
 	enc.TransactionHistory = c.TransactionHistory
 	enc.StateHistory = c.StateHistory
@@ -106,6 +115,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.PersistDiff = c.PersistDiff
 	enc.DiffBlock = c.DiffBlock
 	enc.PruneAncientData = c.PruneAncientData
+	enc.UnsafeAncientPruning = c.UnsafeAncientPruning
 	enc.TrieCleanCache = c.TrieCleanCache
 	enc.TrieDirtyCache = c.TrieDirtyCache
 	enc.TrieTimeout = c.TrieTimeout
@@ -113,6 +123,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.TriesInMemory = c.TriesInMemory
 	enc.TriesVerifyMode = c.TriesVerifyMode
 	enc.Preimages = c.Preimages
+	enc.TrieTimeoutAdaptive = c.TrieTimeoutAdaptive
 	enc.FilterLogCacheSize = c.FilterLogCacheSize
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
@@ -121,8 +132,12 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.DocRoot = c.DocRoot
 	enc.RPCGasCap = c.RPCGasCap
+	enc.RPCGasCaps = c.RPCGasCaps
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
+	enc.RPCEVMTimeouts = c.RPCEVMTimeouts
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.OverrideLondon = c.OverrideLondon
+	enc.OverrideShanghai = c.OverrideShanghai
 	enc.OverrideCancun = c.OverrideCancun
 	enc.OverrideVerkle = c.OverrideVerkle
 	return &enc, nil
@@ -146,6 +161,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		EnableTrustProtocol     *bool
 		PipeCommit              *bool
 		RangeLimit              *bool
+		PrefetchWorkers         *int                   `toml:",omitempty"`
 		TxLookupLimit           *uint64                `toml:",omitempty"`
 		TransactionHistory      *uint64                `toml:",omitempty"`
 		StateHistory            *uint64                `toml:",omitempty"`
@@ -165,6 +181,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		PersistDiff             *bool
 		DiffBlock               *uint64
 		PruneAncientData        *bool
+		UnsafeAncientPruning    *bool `toml:",omitempty"`
 		TrieCleanCache          *int
 		TrieDirtyCache          *int
 		TrieTimeout             *time.Duration
@@ -172,6 +189,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		TriesInMemory           *uint64
 		TriesVerifyMode         *core.VerifyMode
 		Preimages               *bool
+		TrieTimeoutAdaptive     *bool `toml:",omitempty"`
 		FilterLogCacheSize      *int
 		Miner                   *miner.Config
 		TxPool                  *legacypool.Config
@@ -180,10 +198,14 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		EnablePreimageRecording *bool
 		DocRoot                 *string `toml:"-"`
 		RPCGasCap               *uint64
+		RPCGasCaps              map[string]uint64 `toml:",omitempty"`
 		RPCEVMTimeout           *time.Duration
+		RPCEVMTimeouts          map[string]time.Duration `toml:",omitempty"`
 		RPCTxFeeCap             *float64
-		OverrideCancun          *uint64 `toml:",omitempty"`
-		OverrideVerkle          *uint64 `toml:",omitempty"`
+		OverrideLondon          *big.Int `toml:",omitempty"`
+		OverrideShanghai        *uint64  `toml:",omitempty"`
+		OverrideCancun          *uint64  `toml:",omitempty"`
+		OverrideVerkle          *uint64  `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -234,6 +256,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RangeLimit != nil {
 		c.RangeLimit = *dec.RangeLimit
 	}
+	if dec.PrefetchWorkers != nil {
+		c.PrefetchWorkers = *dec.PrefetchWorkers
+	}
 	if dec.TxLookupLimit != nil {
 		c.TxLookupLimit = *dec.TxLookupLimit
 	}
@@ -291,6 +316,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.PruneAncientData != nil {
 		c.PruneAncientData = *dec.PruneAncientData
 	}
+	if dec.UnsafeAncientPruning != nil {
+		c.UnsafeAncientPruning = *dec.UnsafeAncientPruning
+	}
 	if dec.TrieCleanCache != nil {
 		c.TrieCleanCache = *dec.TrieCleanCache
 	}
@@ -312,6 +340,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Preimages != nil {
 		c.Preimages = *dec.Preimages
 	}
+	if dec.TrieTimeoutAdaptive != nil {
+		c.TrieTimeoutAdaptive = *dec.TrieTimeoutAdaptive
+	}
 	if dec.FilterLogCacheSize != nil {
 		c.FilterLogCacheSize = *dec.FilterLogCacheSize
 	}
@@ -336,12 +367,24 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCGasCap != nil {
 		c.RPCGasCap = *dec.RPCGasCap
 	}
+	if dec.RPCGasCaps != nil {
+		c.RPCGasCaps = dec.RPCGasCaps
+	}
 	if dec.RPCEVMTimeout != nil {
 		c.RPCEVMTimeout = *dec.RPCEVMTimeout
 	}
+	if dec.RPCEVMTimeouts != nil {
+		c.RPCEVMTimeouts = dec.RPCEVMTimeouts
+	}
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.OverrideLondon != nil {
+		c.OverrideLondon = dec.OverrideLondon
+	}
+	if dec.OverrideShanghai != nil {
+		c.OverrideShanghai = dec.OverrideShanghai
+	}
 	if dec.OverrideCancun != nil {
 		c.OverrideCancun = dec.OverrideCancun
 	}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -56,6 +57,8 @@ type testBackend struct {
 	engine      consensus.Engine
 	chaindb     ethdb.Database
 	chain       *core.BlockChain
+	gasCaps     map[string]uint64        // Per-namespace gas cap overrides
+	evmTimeouts map[string]time.Duration // Per-method EVM timeout overrides
 
 	refHook func() // Hook is invoked when the requested state is referenced
 	relHook func() // Hook is invoked when the requested state is released
@@ -119,10 +122,18 @@ func (b *testBackend) GetTransaction(ctx context.Context, txHash common.Hash) (*
 	return tx, hash, blockNumber, index, nil
 }
 
-func (b *testBackend) RPCGasCap() uint64 {
+func (b *testBackend) RPCGasCapFor(namespace string) uint64 {
+	if gasCap, ok := b.gasCaps[namespace]; ok {
+		return gasCap
+	}
 	return 25000000
 }
 
+func (b *testBackend) RPCEVMTimeoutFor(method string) (time.Duration, bool) {
+	timeout, ok := b.evmTimeouts[method]
+	return timeout, ok
+}
+
 func (b *testBackend) ChainConfig() *params.ChainConfig {
 	return b.chainConfig
 }
@@ -369,6 +380,85 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+func TestTraceTransactionTimeoutOverride(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts
+	accounts := newAccounts(1)
+	looper := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			// JUMPDEST; PUSH1 0; JUMP
+			looper: {Balance: common.Big0, Code: common.FromHex("0x5b600056")},
+		},
+	}
+	target := common.Hash{}
+	signer := types.HomesteadSigner{}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		// Call the endless loop until it runs out of gas
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), looper, common.Big0, 1000000, b.BaseFee(), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+	api := NewAPI(backend)
+
+	config := &TraceConfig{Config: &logger.Config{DisableStack: true}}
+
+	// Without an override the default timeout leaves enough time to finish
+	if _, err := api.TraceTransaction(context.Background(), target, config); err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	// An override for another method must not apply
+	backend.evmTimeouts = map[string]time.Duration{"debug_traceCall": time.Nanosecond}
+	if _, err := api.TraceTransaction(context.Background(), target, config); err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	// An override for debug_traceTransaction must cut the trace short
+	backend.evmTimeouts = map[string]time.Duration{"debug_traceTransaction": time.Nanosecond}
+	if _, err := api.TraceTransaction(context.Background(), target, config); err == nil || !strings.Contains(err.Error(), "execution timeout") {
+		t.Fatalf("want execution timeout, have %v", err)
+	}
+}
+
+func TestTraceCallGasCapOverride(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts
+	accounts := newAccounts(1)
+	looper := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			// JUMPDEST; PUSH1 0; JUMP
+			looper: {Balance: common.Big0, Code: common.FromHex("0x5b600056")},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.chain.Stop()
+	api := NewAPI(backend)
+
+	// Only the debug cap may bound the gas of a call without an explicit limit
+	backend.gasCaps = map[string]uint64{"eth": 100000, "debug": 200000}
+
+	latest := rpc.LatestBlockNumber
+	config := &TraceCallConfig{TraceConfig: TraceConfig{Config: &logger.Config{DisableStack: true}}}
+	result, err := api.TraceCall(context.Background(), ethapi.TransactionArgs{From: &accounts[0].addr, To: &looper}, rpc.BlockNumberOrHash{BlockNumber: &latest}, config)
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	var have *logger.ExecutionResult
+	if err := json.Unmarshal(result.(json.RawMessage), &have); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+	if !have.Failed || have.Gas != 200000 {
+		t.Fatalf("gas cap mismatch: have gas %d (failed %v), want 200000 (failed true)", have.Gas, have.Failed)
+	}
+}
+
 func TestTraceBlock(t *testing.T) {
 	t.Parallel()
 
@@ -864,7 +954,7 @@ func TestTraceChain(t *testing.T) {
 
 		from, _ := api.blockByNumber(context.Background(), rpc.BlockNumber(c.start))
 		to, _ := api.blockByNumber(context.Background(), rpc.BlockNumber(c.end))
-		resCh := api.traceChain(from, to, c.config, nil)
+		resCh := api.traceChain("debug_traceChain", from, to, c.config, nil)
 
 		next := c.start + 1
 		for result := range resCh {
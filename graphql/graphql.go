@@ -1118,6 +1118,9 @@ func (c *CallResult) Status() hexutil.Uint64 {
 	return c.status
 }
 
+// Call executes a message call against the block's state. Like the other
+// GraphQL call paths it uses the global gas cap, as the per-namespace caps
+// only apply to RPC namespaces.
 func (b *Block) Call(ctx context.Context, args struct {
 	Data ethapi.TransactionArgs
 }) (*CallResult, error) {
@@ -1190,7 +1190,11 @@ func (e *revertError) ErrorData() interface{} {
 // Note, this function doesn't make and changes in the state/blockchain and is
 // useful to execute and retrieve values.
 func (s *BlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, blockOverrides *BlockOverrides) (hexutil.Bytes, error) {
-	result, err := DoCall(ctx, s.b, args, blockNrOrHash, overrides, blockOverrides, s.b.RPCEVMTimeout(), s.b.RPCGasCap())
+	timeout := s.b.RPCEVMTimeout()
+	if override, ok := s.b.RPCEVMTimeoutFor("eth_call"); ok {
+		timeout = override
+	}
+	result, err := DoCall(ctx, s.b, args, blockNrOrHash, overrides, blockOverrides, timeout, s.b.RPCGasCapFor("eth"))
 	if err != nil {
 		return nil, err
 	}
@@ -1341,7 +1345,7 @@ func (s *BlockChainAPI) EstimateGas(ctx context.Context, args TransactionArgs, b
 	if blockNrOrHash != nil {
 		bNrOrHash = *blockNrOrHash
 	}
-	return DoEstimateGas(ctx, s.b, args, bNrOrHash, overrides, s.b.RPCGasCap())
+	return DoEstimateGas(ctx, s.b, args, bNrOrHash, overrides, s.b.RPCGasCapFor("eth"))
 }
 
 func (s *BlockChainAPI) needToReplay(ctx context.Context, block *types.Block, accounts []common.Address) (bool, error) {
@@ -1772,7 +1776,7 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 	}
 	// If the gas amount is not set, default to RPC gas cap.
 	if args.Gas == nil {
-		tmp := hexutil.Uint64(b.RPCGasCap())
+		tmp := hexutil.Uint64(b.RPCGasCapFor("eth"))
 		args.Gas = &tmp
 	}
 
@@ -1804,7 +1808,7 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 		statedb := db.Copy()
 		// Set the accesslist to the last al
 		args.AccessList = &accessList
-		msg, err := args.ToMessage(b.RPCGasCap(), header.BaseFee)
+		msg, err := args.ToMessage(b.RPCGasCapFor("eth"), header.BaseFee)
 		if err != nil {
 			return nil, 0, nil, err
 		}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -342,6 +343,7 @@ type testBackend struct {
 	db      ethdb.Database
 	chain   *core.BlockChain
 	pending *types.Block
+	gasCaps map[string]uint64 // Per-namespace gas cap overrides
 }
 
 func newTestBackend(t *testing.T, n int, gspec *core.Genesis, generator func(i int, b *core.BlockGen)) *testBackend {
@@ -395,6 +397,15 @@ func (b testBackend) RPCEVMTimeout() time.Duration      { return time.Second }
 func (b testBackend) RPCTxFeeCap() float64              { return 0 }
 func (b testBackend) UnprotectedAllowed() bool          { return false }
 func (b testBackend) SetHead(number uint64)             {}
+func (b testBackend) RPCEVMTimeoutFor(method string) (time.Duration, bool) {
+	return 0, false
+}
+func (b testBackend) RPCGasCapFor(namespace string) uint64 {
+	if gasCap, ok := b.gasCaps[namespace]; ok {
+		return gasCap
+	}
+	return b.RPCGasCap()
+}
 func (b testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
 	if number == rpc.LatestBlockNumber {
 		return b.chain.CurrentBlock(), nil
@@ -649,6 +660,42 @@ func TestEstimateGas(t *testing.T) {
 	}
 }
 
+func TestEstimateGasNamespaceCap(t *testing.T) {
+	t.Parallel()
+	var (
+		accounts = newAccounts(2)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		call = TransactionArgs{
+			From:  &accounts[0].addr,
+			To:    &accounts[1].addr,
+			Value: (*hexutil.Big)(big.NewInt(1000)),
+		}
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+
+	// A cap for another namespace must not affect eth_estimateGas
+	backend.gasCaps = map[string]uint64{"debug": 20000}
+	result, err := NewBlockChainAPI(backend).EstimateGas(context.Background(), call, &latest, nil)
+	if err != nil {
+		t.Fatalf("failed to estimate gas: %v", err)
+	}
+	if uint64(result) != params.TxGas {
+		t.Fatalf("gas estimate mismatch: have %d, want %d", result, params.TxGas)
+	}
+	// An eth cap below the intrinsic gas must make the estimate fail
+	backend.gasCaps = map[string]uint64{"eth": 20000}
+	_, err = NewBlockChainAPI(backend).EstimateGas(context.Background(), call, &latest, nil)
+	if err == nil || !strings.Contains(err.Error(), "allowance (20000)") {
+		t.Fatalf("expected eth gas cap to apply, have %v", err)
+	}
+}
+
 func TestCall(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
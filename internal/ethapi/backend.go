@@ -50,10 +50,12 @@ type Backend interface {
 	ChainDb() ethdb.Database
 	AccountManager() *accounts.Manager
 	ExtRPCEnabled() bool
-	RPCGasCap() uint64            // global gas cap for eth_call over rpc: DoS protection
-	RPCEVMTimeout() time.Duration // global timeout for eth_call over rpc: DoS protection
-	RPCTxFeeCap() float64         // global tx fee cap for all transaction related APIs
-	UnprotectedAllowed() bool     // allows only for EIP155 transactions.
+	RPCGasCap() uint64                                    // global gas cap for eth_call over rpc: DoS protection
+	RPCGasCapFor(namespace string) uint64                 // gas cap for eth_call variants of the given rpc namespace
+	RPCEVMTimeout() time.Duration                         // global timeout for eth_call over rpc: DoS protection
+	RPCEVMTimeoutFor(method string) (time.Duration, bool) // per-method override of the EVM execution timeout
+	RPCTxFeeCap() float64                                 // global tx fee cap for all transaction related APIs
+	UnprotectedAllowed() bool                             // allows only for EIP155 transactions.
 
 	// Blockchain API
 	SetHead(number uint64)
@@ -271,6 +271,10 @@ func (b *backendMock) RPCEVMTimeout() time.Duration      { return time.Second }
 func (b *backendMock) RPCTxFeeCap() float64              { return 0 }
 func (b *backendMock) UnprotectedAllowed() bool          { return false }
 func (b *backendMock) SetHead(number uint64)             {}
+func (b *backendMock) RPCEVMTimeoutFor(method string) (time.Duration, bool) {
+	return 0, false
+}
+func (b *backendMock) RPCGasCapFor(namespace string) uint64 { return 0 }
 func (b *backendMock) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
 	return nil, nil
 }
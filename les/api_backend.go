@@ -312,10 +312,18 @@ func (b *LesApiBackend) RPCGasCap() uint64 {
 	return b.eth.config.RPCGasCap
 }
 
+func (b *LesApiBackend) RPCGasCapFor(namespace string) uint64 {
+	return b.eth.config.GasCapFor(namespace)
+}
+
 func (b *LesApiBackend) RPCEVMTimeout() time.Duration {
 	return b.eth.config.RPCEVMTimeout
 }
 
+func (b *LesApiBackend) RPCEVMTimeoutFor(method string) (time.Duration, bool) {
+	return b.eth.config.EVMTimeoutFor(method)
+}
+
 func (b *LesApiBackend) RPCTxFeeCap() float64 {
 	return b.eth.config.RPCTxFeeCap
 }
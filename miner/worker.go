@@ -224,7 +224,7 @@ type worker struct {
 func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus.Engine, eth Backend, mux *event.TypeMux, isLocalBlock func(header *types.Header) bool, init bool) *worker {
 	recentMinedBlocks, _ := lru.New(recentMinedCacheLimit)
 	worker := &worker{
-		prefetcher:         core.NewStatePrefetcher(chainConfig, eth.BlockChain(), engine),
+		prefetcher:         core.NewStatePrefetcher(chainConfig, eth.BlockChain(), engine, eth.BlockChain().PrefetchWorkers()),
 		config:             config,
 		chainConfig:        chainConfig,
 		engine:             engine,